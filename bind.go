@@ -0,0 +1,118 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// BindError collects the per-field validation errors produced by BindParams,
+// keyed by the struct field name
+type BindError map[string]error
+
+// Error implements the error interface, joining all field errors into one message
+func (e BindError) Error() string {
+	msgs := make([]string, 0, len(e))
+	for field, err := range e {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", field, err))
+	}
+	return "router: binding failed: " + strings.Join(msgs, "; ")
+}
+
+// ErrBindTarget is returned by BindParams when dst is not a pointer to a struct
+var ErrBindTarget = errors.New("router: BindParams requires a pointer to a struct")
+
+// BindParams populates dst (a pointer to a struct) from the request, reading
+// each field from its own independent source by struct tag: `param:"id"`
+// binds the route param named "id", `query:"page"` binds the URL query
+// parameter (ignoring any form body), and `form:"email"` binds the
+// POST/PUT/PATCH body value (ignoring the query string). This keeps the
+// three sources independent, so a route param and a query string can share a
+// name without colliding. Fields with no matching tag, or whose value is
+// empty, are left unchanged. Per-field conversion failures are collected and
+// returned as a BindError
+func (c *Context) BindParams(dst interface{}) error {
+	if c.Request.Form == nil {
+		if err := c.parseRequest(); err != nil {
+			return err
+		}
+	}
+
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return ErrBindTarget
+	}
+	elem := v.Elem()
+	t := elem.Type()
+
+	bindErrs := BindError{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := elem.Field(i)
+		if !fv.CanSet() {
+			continue
+		}
+
+		raw, ok := c.fieldTagValue(field)
+		if !ok || raw == "" {
+			continue
+		}
+
+		if err := setFieldValue(fv, raw); err != nil {
+			bindErrs[field.Name] = err
+		}
+	}
+
+	if len(bindErrs) > 0 {
+		return bindErrs
+	}
+	return nil
+}
+
+// fieldTagValue returns the value to bind field from - sourced from
+// field's param, query or form tag, each read from its own independent
+// map rather than the flattened map Context.Params returns - and whether one
+// of those tags was present
+func (c *Context) fieldTagValue(field reflect.StructField) (string, bool) {
+	if key, ok := field.Tag.Lookup("param"); ok && key != "" {
+		return c.routeParam(key), true
+	}
+	if key, ok := field.Tag.Lookup("query"); ok && key != "" {
+		return c.Request.URL.Query().Get(key), true
+	}
+	if key, ok := field.Tag.Lookup("form"); ok && key != "" {
+		return c.Request.PostForm.Get(key), true
+	}
+	return "", false
+}
+
+// setFieldValue converts raw into fv's type and sets it
+func setFieldValue(fv reflect.Value, raw string) error {
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(i)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	default:
+		return fmt.Errorf("router: unsupported bind type %s", fv.Kind())
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBindParamsFromQueryAndRoute(t *testing.T) {
+	type input struct {
+		ID     string `param:"id"`
+		Page   int    `query:"page"`
+		Active bool   `query:"active"`
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42?page=3&active=true", nil)
+	ctx := &Context{Request: req, routeParams: map[string]string{"id": "42"}}
+
+	var in input
+	if err := ctx.BindParams(&in); err != nil {
+		t.Fatalf("BindParams: %v", err)
+	}
+
+	if in.ID != "42" || in.Page != 3 || !in.Active {
+		t.Fatalf("unexpected bind result: %+v", in)
+	}
+}
+
+// TestBindParamsSourcesAreIndependent guards against param/query/form tags
+// collapsing onto Context.Params' flattened map, where a route param and a
+// form value sharing a name would resolve to the same value.
+func TestBindParamsSourcesAreIndependent(t *testing.T) {
+	type input struct {
+		RouteID string `param:"id"`
+		QueryID string `query:"id"`
+		FormID  string `form:"id"`
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/users/route-value?id=query-value", strings.NewReader("id=form-value"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	ctx := &Context{Request: req, routeParams: map[string]string{"id": "route-value"}}
+
+	var in input
+	if err := ctx.BindParams(&in); err != nil {
+		t.Fatalf("BindParams: %v", err)
+	}
+
+	if in.RouteID != "route-value" || in.QueryID != "query-value" || in.FormID != "form-value" {
+		t.Fatalf("expected independently bound values, got %+v", in)
+	}
+}
+
+func TestBindParamsReportsFieldErrors(t *testing.T) {
+	type input struct {
+		Page int `query:"page"`
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/?page=not-a-number", nil)
+	ctx := &Context{Request: req}
+
+	var in input
+	err := ctx.BindParams(&in)
+
+	bindErr, ok := err.(BindError)
+	if !ok {
+		t.Fatalf("expected BindError, got %T: %v", err, err)
+	}
+	if _, ok := bindErr["Page"]; !ok {
+		t.Fatalf("expected an error for field Page, got %v", bindErr)
+	}
+}
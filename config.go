@@ -0,0 +1,11 @@
+package router
+
+// Config is the minimal configuration interface required by the router and
+// Context, allowing callers to plug in their own config store
+type Config interface {
+	// Config returns the value for key, or an empty string if it is not set
+	Config(key string) string
+
+	// Production reports whether the app is running in a production environment
+	Production() bool
+}
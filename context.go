@@ -1,10 +1,12 @@
 package router
 
 import (
+	"context"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"strings"
+	"time"
 )
 
 // ContextI is a request context wrapping a response writer and the request details
@@ -46,21 +48,82 @@ type Context struct {
 	// Errors which occured during routing or rendering
 	Errors []error
 
+	// routeParams holds the path params the router extracted when matching
+	// this request, keyed by segment name. This lives on the Context, not the
+	// shared *Route, since a Route is a long-lived singleton reused across
+	// concurrent requests
+	routeParams map[string]string
+
 	// The context log passed from router
 	logger Logger
 
 	config Config
+
+	// maxMemory and maxUploadSize are set by the router from its own defaults
+	// or the matched route's override, and used by FormFile/FormFiles/MultipartIter
+	maxMemory     int64
+	maxUploadSize int64
+
+	// secret is the router's signing secret, used by SignedRedirect and RedirectStatus
+	secret []byte
+
+	// ctx is the request's context.Context, wired to Request.Context() at
+	// construction so cancellation propagates from the client. Middleware
+	// that calls WithValue replaces it with a derived context
+	ctx context.Context
+}
+
+// Deadline promotes the request's context.Context deadline onto Context
+func (c *Context) Deadline() (time.Time, bool) {
+	return c.ctx.Deadline()
+}
+
+// Done promotes the request's context.Context Done channel onto Context; it
+// closes when the client disconnects or a Timeout middleware expires
+func (c *Context) Done() <-chan struct{} {
+	return c.ctx.Done()
+}
+
+// Err promotes the request's context.Context Err onto Context
+func (c *Context) Err() error {
+	return c.ctx.Err()
+}
+
+// Value promotes the request's context.Context Value lookup onto Context
+func (c *Context) Value(key interface{}) interface{} {
+	return c.ctx.Value(key)
+}
+
+// WithValue stores val under key in the request-scoped context, so
+// downstream middleware and the handler can retrieve it with Get or Value.
+// Typical uses are the authenticated user, tenant, or a trace id
+func (c *Context) WithValue(key, val interface{}) {
+	c.ctx = context.WithValue(c.ctx, key, val)
 }
 
-// Logf logs the given message and arguments using our logger
+// Get retrieves a value previously stored with WithValue, or nil if key was
+// never set
+func (c *Context) Get(key interface{}) interface{} {
+	return c.ctx.Value(key)
+}
+
+// Logf logs the given message and arguments using our logger, if one is set;
+// a zero-value Context (as built directly in tests) has no logger and simply
+// discards the message
 func (c *Context) Logf(format string, v ...interface{}) {
+	if c.logger == nil {
+		return
+	}
 	c.logger.Printf(format, v...)
 }
 
 // TODO: Replace usages of Log with Logf, then remove  v ...interface{}
 
-// Log logs the given message using our logger
+// Log logs the given message using our logger, if one is set
 func (c *Context) Log(format string, v ...interface{}) {
+	if c.logger == nil {
+		return
+	}
 	c.logger.Printf(format, v...)
 }
 
@@ -85,11 +148,10 @@ func (c *Context) Params() (Params, error) {
 		}
 	}
 
-	// Now add the route params to this list of params
-	if c.Route.Params == nil {
-		c.Route.Parse(c.Path)
-	}
-	for k, v := range c.Route.Params {
+	// Now add the route params to this list of params; these were extracted
+	// when the router matched this request, and live on the Context (not the
+	// shared *Route) since a Route is reused across concurrent requests
+	for k, v := range c.routeParams {
 		params.Add(k, v)
 	}
 
@@ -122,8 +184,61 @@ func (c *Context) ParamInt(key string) int64 {
 	return params.GetInt(key)
 }
 
+// ParamFloat retreives a single param value as float64, ignoring multiple values
+// This may trigger a parse of the request and route
+func (c *Context) ParamFloat(key string) float64 {
+	params, err := c.Params()
+	if err != nil {
+		c.Logf("#error parsing request:", err)
+		return 0
+	}
+
+	return params.GetFloat(key)
+}
+
+// ParamBool retreives a single param value as bool, ignoring multiple values.
+// Recognises the same values as strconv.ParseBool ("1", "t", "true", ... and
+// their false counterparts); anything else is treated as false
+func (c *Context) ParamBool(key string) bool {
+	params, err := c.Params()
+	if err != nil {
+		c.Logf("#error parsing request:", err)
+		return false
+	}
+
+	return params.GetBool(key)
+}
+
+// ParamTime retreives a single param value parsed as a time.Time using layout
+// (see time.Parse). Returns the zero Time if the param is missing or does not
+// match layout
+func (c *Context) ParamTime(key, layout string) time.Time {
+	params, err := c.Params()
+	if err != nil {
+		c.Logf("#error parsing request:", err)
+		return time.Time{}
+	}
+
+	return params.GetTime(key, layout)
+}
+
+// ParamUUID retreives a single param value as a UUID string, validating it
+// looks like a UUID (8-4-4-4-12 hex digits). Returns an error if the param is
+// missing or malformed
+func (c *Context) ParamUUID(key string) (string, error) {
+	params, err := c.Params()
+	if err != nil {
+		return "", err
+	}
+
+	return params.GetUUID(key)
+}
+
 // ParamFiles retreives the files from params
 // NB this requires ParseMultipartForm to be called
+//
+// Deprecated: ParamFiles silently skips non-file parts and never checks
+// sizes. Use FormFile / FormFiles instead
 func (c *Context) ParamFiles(key string) ([]*multipart.Part, error) {
 
 	var parts []*multipart.Part
@@ -182,11 +297,11 @@ func Redirect(context *Context, path string) {
 // We don't accept external or relative paths for security reasons
 func RedirectStatus(context *Context, path string, status int) {
 
-	// Check for redirect in params, if it is valid, use that instead of default path
-	// This is potentially surprising behaviour - find where used and REMOVE IT FIXME:URGENT
-	redirect := context.Param("redirect")
-	if len(redirect) > 0 {
-		path = redirect
+	// Honor a client-supplied redirect target only if it carries a valid
+	// HMAC signature from SignedRedirect - replaces the old behaviour of
+	// trusting an arbitrary "redirect" query param outright
+	if signed, ok := context.verifiedRedirect(); ok {
+		path = signed
 	}
 
 	// We check this is an internal path - to redirect externally use http.Redirect directly
@@ -232,11 +347,5 @@ func (c *Context) parseRequest() error {
 
 // routeParam returns a param from the route - this may return empty string
 func (c *Context) routeParam(key string) string {
-
-	// If we don't have params already, load them
-	if c.Route.Params == nil {
-		c.Route.Parse(c.Path)
-	}
-
-	return c.Route.Params[key]
+	return c.routeParams[key]
 }
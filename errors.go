@@ -0,0 +1,96 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+)
+
+// HTTPError is an error that carries the HTTP status it should produce, so
+// handlers can return it directly and let the router render it consistently
+type HTTPError struct {
+	Code    int
+	Message string
+	Cause   error
+}
+
+// Error implements the error interface
+func (e *HTTPError) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Cause)
+	}
+	return e.Message
+}
+
+// Unwrap exposes Cause to errors.Is / errors.As
+func (e *HTTPError) Unwrap() error {
+	return e.Cause
+}
+
+// Error records and returns an HTTPError for code and msg, so a handler can
+// `return c.Error(http.StatusBadRequest, "bad input")`
+func (c *Context) Error(code int, msg string) error {
+	err := &HTTPError{Code: code, Message: msg}
+	c.Errors = append(c.Errors, err)
+	return err
+}
+
+// NotFound records and returns a 404 HTTPError
+func (c *Context) NotFound() error {
+	return c.Error(http.StatusNotFound, "not found")
+}
+
+// Unauthorized records and returns a 401 HTTPError
+func (c *Context) Unauthorized() error {
+	return c.Error(http.StatusUnauthorized, "unauthorized")
+}
+
+// ErrorHandler renders err (and any errors accumulated on c.Errors) to the
+// client; register a custom one via Router.ErrorHandler to change how errors
+// are presented
+type ErrorHandler func(*Context, error)
+
+// DefaultErrorHandler renders err according to the request's Accept header:
+// JSON as {"error":..., "code":...}, a minimal HTML page, or otherwise a plain
+// text message
+func DefaultErrorHandler(c *Context, err error) {
+	code, msg := http.StatusInternalServerError, "internal server error"
+	if e, ok := err.(*HTTPError); ok {
+		code, msg = e.Code, e.Message
+	}
+
+	accept := c.Request.Header.Get("Accept")
+	switch {
+	case strings.Contains(accept, "json"):
+		c.Writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		c.Writer.WriteHeader(code)
+		json.NewEncoder(c.Writer).Encode(map[string]interface{}{
+			"error": msg,
+			"code":  code,
+		})
+	case strings.Contains(accept, "html"):
+		c.Writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+		c.Writer.WriteHeader(code)
+		fmt.Fprintf(c.Writer, "<html><body><h1>%d</h1><p>%s</p></body></html>", code, html.EscapeString(msg))
+	default:
+		c.Writer.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		c.Writer.WriteHeader(code)
+		fmt.Fprintln(c.Writer, msg)
+	}
+
+	// Always log the triggering error, regardless of its concrete type, so an
+	// operator can trace a generic "internal server error" response back to
+	// its cause; the client only ever sees msg
+	c.Logf("#error %s: %v", msg, err)
+
+	// Surface any other errors accumulated on the Context (e.g. by middleware
+	// that recorded a problem but let the request continue) alongside err
+	for _, accumulated := range c.Errors {
+		if accumulated == err {
+			continue
+		}
+		c.Logf("#error (accumulated): %v", accumulated)
+	}
+}
@@ -0,0 +1,24 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestDefaultErrorHandlerEscapesHTML guards against a reflected XSS bug where
+// an HTTPError.Message built from request-derived content was interpolated
+// unescaped into the HTML error page.
+func TestDefaultErrorHandlerEscapesHTML(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "text/html")
+	w := httptest.NewRecorder()
+	ctx := &Context{Writer: w, Request: req}
+
+	DefaultErrorHandler(ctx, &HTTPError{Code: http.StatusBadRequest, Message: `<script>alert(1)</script>`})
+
+	if got := w.Body.String(); strings.Contains(got, "<script>") {
+		t.Fatalf("expected message to be HTML-escaped, got body: %q", got)
+	}
+}
@@ -0,0 +1,7 @@
+package router
+
+// Logger is the minimal logging interface required by the router and
+// Context; log.Logger and most structured loggers satisfy this directly
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
@@ -0,0 +1,83 @@
+package router
+
+import (
+	"io"
+	"net/http"
+)
+
+// ResponseData describes the response produced by a Handler: status, headers
+// and body are buffered here rather than written directly, so Middleware can
+// inspect and rewrite them (gzip, CORS, request-id, timing, ...) before the
+// final response reaches the client
+type ResponseData struct {
+	Status int
+	Header http.Header
+
+	// Body is used when the response is small enough to buffer in memory
+	Body []byte
+
+	// Reader, if set, takes precedence over Body and is copied to the client
+	Reader io.Reader
+
+	// Err holds any error the handler returned, for middleware that wants to
+	// inspect it without intercepting the normal error-handling path
+	Err error
+}
+
+// Handler handles a request and returns the ResponseData to be written,
+// allowing middleware to inspect or rewrite it before it reaches the client
+type Handler func(*Context) ResponseData
+
+// Middleware wraps a Handler to add cross-cutting behaviour such as gzip,
+// CORS, request-id tagging, auth or timing
+type Middleware func(Handler) Handler
+
+// HandlerFunc is the original handler signature: it writes directly to the
+// Context and reports failure via its error return
+type HandlerFunc func(*Context) error
+
+// Adapt wraps a HandlerFunc as a Handler, so existing handlers keep working
+// unchanged under the middleware chain. Errors returned by fn are recorded on
+// the Context and surfaced on the returned ResponseData
+func Adapt(fn HandlerFunc) Handler {
+	return func(c *Context) ResponseData {
+		if err := fn(c); err != nil {
+			c.Errors = append(c.Errors, err)
+			return ResponseData{Err: err}
+		}
+		return ResponseData{}
+	}
+}
+
+// Chain composes mws around h, running the first middleware outermost so it
+// sees the request before, and the response after, all the others
+func Chain(h Handler, mws ...Middleware) Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
+
+// writeResponse writes a ResponseData to w. A Handler that wrote directly to
+// the Context (the common case via Adapt) returns a zero ResponseData here,
+// so writeResponse only acts when the handler actually populated it
+func writeResponse(w http.ResponseWriter, resp ResponseData) {
+	for key, values := range resp.Header {
+		for _, v := range values {
+			w.Header().Add(key, v)
+		}
+	}
+
+	if resp.Status != 0 {
+		w.WriteHeader(resp.Status)
+	}
+
+	if resp.Reader != nil {
+		io.Copy(w, resp.Reader)
+		return
+	}
+
+	if len(resp.Body) > 0 {
+		w.Write(resp.Body)
+	}
+}
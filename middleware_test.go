@@ -0,0 +1,128 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestChainRunsMiddlewareOutermostToInnermost guards Chain's ordering
+// contract: the first Middleware passed is the outermost, seeing the
+// request before (and the response after) every middleware that follows.
+func TestChainRunsMiddlewareOutermostToInnermost(t *testing.T) {
+	var order []string
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(c *Context) ResponseData {
+				order = append(order, name+":before")
+				resp := next(c)
+				order = append(order, name+":after")
+				return resp
+			}
+		}
+	}
+
+	h := Chain(func(c *Context) ResponseData {
+		order = append(order, "handler")
+		return ResponseData{Status: http.StatusOK}
+	}, record("outer"), record("inner"))
+
+	h(&Context{})
+
+	want := []string{"outer:before", "inner:before", "handler", "inner:after", "outer:after"}
+	if len(order) != len(want) {
+		t.Fatalf("expected order %v, got %v", want, order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("expected order %v, got %v", want, order)
+		}
+	}
+}
+
+// TestAdaptRecordsHandlerFuncError guards Adapt's contract: a HandlerFunc's
+// error return must still surface on both Context.Errors and the resulting
+// ResponseData, so existing error-returning handlers keep working unchanged
+// under the middleware chain.
+func TestAdaptRecordsHandlerFuncError(t *testing.T) {
+	want := errors.New("boom")
+	h := Adapt(func(c *Context) error { return want })
+
+	c := &Context{}
+	resp := h(c)
+
+	if resp.Err != want {
+		t.Fatalf("expected ResponseData.Err to be %v, got %v", want, resp.Err)
+	}
+	if len(c.Errors) != 1 || c.Errors[0] != want {
+		t.Fatalf("expected err recorded on Context.Errors, got %v", c.Errors)
+	}
+}
+
+// TestRouterUseAndRouteWithCompose guards against global and per-route
+// middleware stacks not composing: Router.Use should wrap every route ahead
+// of that route's own Route.With stack.
+func TestRouterUseAndRouteWithCompose(t *testing.T) {
+	rt := New(testLogger{t}, testConfig{})
+
+	var order []string
+	rt.Use(func(next Handler) Handler {
+		return func(c *Context) ResponseData {
+			order = append(order, "global")
+			return next(c)
+		}
+	})
+
+	route := rt.Get("/ping", func(c *Context) error {
+		order = append(order, "handler")
+		return nil
+	})
+	route.With(func(next Handler) Handler {
+		return func(c *Context) ResponseData {
+			order = append(order, "route")
+			return next(c)
+		}
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, req)
+
+	want := []string{"global", "route", "handler"}
+	if len(order) != len(want) {
+		t.Fatalf("expected order %v, got %v", want, order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("expected order %v, got %v", want, order)
+		}
+	}
+}
+
+// TestGroupPrefixesPathAndAppliesMiddleware guards the Group feature: routes
+// registered through a RouteGroup must be reachable under the group's
+// prefix, and the group's middleware must run for them.
+func TestGroupPrefixesPathAndAppliesMiddleware(t *testing.T) {
+	rt := New(testLogger{t}, testConfig{})
+
+	var ran bool
+	group := rt.Group("/api", func(next Handler) Handler {
+		return func(c *Context) ResponseData {
+			ran = true
+			return next(c)
+		}
+	})
+	group.Get("/users", func(c *Context) error { return nil })
+
+	req := httptest.NewRequest(http.MethodGet, "/api/users", nil)
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, req)
+
+	if !ran {
+		t.Fatalf("expected group middleware to run")
+	}
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+}
@@ -0,0 +1,86 @@
+package router
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ErrInvalidUUID is returned by Params.GetUUID when the value is not a
+// well-formed UUID
+var ErrInvalidUUID = errors.New("router: invalid uuid")
+
+// Params holds request parameter values keyed by name, with support for
+// multiple values per key (as produced by query strings and forms)
+type Params map[string][]string
+
+// Add appends a value for key, preserving any values already present
+func (p Params) Add(key, value string) {
+	p[key] = append(p[key], value)
+}
+
+// Get returns the first value for key, or an empty string if key is not present
+func (p Params) Get(key string) string {
+	if vs := p[key]; len(vs) > 0 {
+		return vs[0]
+	}
+	return ""
+}
+
+// GetAll returns all values for key
+func (p Params) GetAll(key string) []string {
+	return p[key]
+}
+
+// GetInt returns the first value for key parsed as an int64, or 0 if key is
+// not present or does not parse as an integer
+func (p Params) GetInt(key string) int64 {
+	i, err := strconv.ParseInt(p.Get(key), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return i
+}
+
+// GetFloat returns the first value for key parsed as a float64, or 0 if key
+// is not present or does not parse as a float
+func (p Params) GetFloat(key string) float64 {
+	f, err := strconv.ParseFloat(p.Get(key), 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
+
+// GetBool returns the first value for key parsed via strconv.ParseBool, or
+// false if key is not present or does not parse as a bool
+func (p Params) GetBool(key string) bool {
+	b, err := strconv.ParseBool(p.Get(key))
+	if err != nil {
+		return false
+	}
+	return b
+}
+
+// GetTime returns the first value for key parsed as a time.Time using
+// layout, or the zero Time if key is not present or does not match layout
+func (p Params) GetTime(key, layout string) time.Time {
+	t, err := time.Parse(layout, p.Get(key))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// GetUUID returns the first value for key, validated as a UUID. Returns
+// ErrInvalidUUID if the value is missing or malformed
+func (p Params) GetUUID(key string) (string, error) {
+	v := p.Get(key)
+	if !uuidPattern.MatchString(v) {
+		return "", ErrInvalidUUID
+	}
+	return v, nil
+}
@@ -0,0 +1,142 @@
+package router
+
+import (
+	"regexp"
+	"strings"
+)
+
+// routeSegment is one compiled segment of a Route's pattern: either a
+// literal path segment, a named param (optionally constrained by a regex,
+// and optionally itself optional), or a catch-all that consumes the
+// remainder of the path
+type routeSegment struct {
+	literal  string
+	name     string
+	catchAll bool
+	optional bool
+	re       *regexp.Regexp
+}
+
+// Route describes a single routed pattern: the HTTP method and path pattern
+// it matches, and its handler and middleware stack. A Route is a long-lived
+// singleton shared across concurrent requests, so Parse must not mutate it -
+// matched params are request-scoped and live on the Context instead
+type Route struct {
+	// Method is the HTTP method this route matches, e.g. "GET"
+	Method string
+
+	// Pattern is the path pattern this route matches, e.g. "/users/{id:\d+}"
+	Pattern string
+
+	// Regexes holds the compiled constraint regexes declared in Pattern, in
+	// the order they appear, for callers that want to introspect a route
+	Regexes []*regexp.Regexp
+
+	// MaxUploadSize overrides the router's default MaxUploadSize for this
+	// route; 0 means use the router default
+	MaxUploadSize int64
+
+	segments []routeSegment
+
+	handler    Handler
+	middleware []Middleware
+}
+
+// With composes the given middleware around this route's handler, applied
+// after the router's global middleware. Returns the route for chaining
+func (rt *Route) With(mws ...Middleware) *Route {
+	rt.middleware = append(rt.middleware, mws...)
+	return rt
+}
+
+// compile parses Pattern into segments, compiling any "{name:constraint}"
+// regex constraints. A name ending in "?" (e.g. "{format?}" or
+// "{format?:json|html}") marks the segment optional: Parse allows the path
+// to end before it instead of failing to match. As with a catch-all,
+// optional segments are only meaningful at the end of Pattern - if the path
+// supplies enough segments to reach one, it is bound greedily rather than
+// deferring to a later mandatory segment. It must be called once at route
+// registration time, before the route can be matched concurrently - it is
+// the only part of a Route's setup that mutates the struct, so it must
+// never run lazily from Parse, which is called from concurrent request
+// goroutines
+func (rt *Route) compile() {
+	for _, raw := range strings.Split(strings.Trim(rt.Pattern, "/"), "/") {
+		if !strings.HasPrefix(raw, "{") || !strings.HasSuffix(raw, "}") {
+			rt.segments = append(rt.segments, routeSegment{literal: raw})
+			continue
+		}
+
+		inner := strings.Trim(raw, "{}")
+		name, constraint := inner, ""
+		if idx := strings.Index(inner, ":"); idx >= 0 {
+			name, constraint = inner[:idx], inner[idx+1:]
+		}
+
+		seg := routeSegment{name: name}
+		if strings.HasSuffix(seg.name, "?") {
+			seg.name = strings.TrimSuffix(seg.name, "?")
+			seg.optional = true
+		}
+
+		switch constraint {
+		case "":
+			// no constraint: matches any single path segment
+		case "*":
+			// catch-all: matches the remainder of the path, must be the last segment
+			seg.catchAll = true
+		default:
+			re := regexp.MustCompile("^(?:" + constraint + ")$")
+			seg.re = re
+			rt.Regexes = append(rt.Regexes, re)
+		}
+		rt.segments = append(rt.segments, seg)
+	}
+}
+
+// Parse matches path against the route's pattern, returning the named
+// segment values found (validated against their constraint regex, if any)
+// and whether path matches. A missing optional segment is simply left out of
+// the returned params rather than failing the match. Parse reads rt.segments
+// but never writes to rt, so it is safe to call concurrently from multiple
+// request goroutines sharing the same *Route
+func (rt *Route) Parse(path string) (map[string]string, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	params := map[string]string{}
+
+	pi := 0
+	for _, seg := range rt.segments {
+		if seg.catchAll {
+			params[seg.name] = strings.Join(parts[pi:], "/")
+			pi = len(parts)
+			continue
+		}
+
+		if pi >= len(parts) {
+			if seg.optional {
+				continue
+			}
+			return nil, false
+		}
+		part := parts[pi]
+		pi++
+
+		if seg.name == "" {
+			if seg.literal != part {
+				return nil, false
+			}
+			continue
+		}
+
+		if seg.re != nil && !seg.re.MatchString(part) {
+			return nil, false
+		}
+		params[seg.name] = part
+	}
+
+	if pi != len(parts) {
+		return nil, false
+	}
+
+	return params, true
+}
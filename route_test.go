@@ -0,0 +1,92 @@
+package router
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestRouteParseConstraints(t *testing.T) {
+	route := &Route{Pattern: "/accounts/{id:\\d+}"}
+	route.compile()
+
+	params, ok := route.Parse("/accounts/42")
+	if !ok {
+		t.Fatalf("expected /accounts/42 to match")
+	}
+	if params["id"] != "42" {
+		t.Fatalf("expected id=42, got %q", params["id"])
+	}
+
+	if _, ok := route.Parse("/accounts/abc"); ok {
+		t.Fatalf("expected /accounts/abc to fail the \\d+ constraint")
+	}
+}
+
+func TestRouteParseOptionalSegment(t *testing.T) {
+	route := &Route{Pattern: "/posts/{id}/{format?}"}
+	route.compile()
+
+	params, ok := route.Parse("/posts/42")
+	if !ok {
+		t.Fatalf("expected the path with the optional segment omitted to match")
+	}
+	if params["id"] != "42" {
+		t.Fatalf("expected id=42, got %q", params["id"])
+	}
+	if _, present := params["format"]; present {
+		t.Fatalf("expected format to be absent, got %q", params["format"])
+	}
+
+	params, ok = route.Parse("/posts/42/html")
+	if !ok {
+		t.Fatalf("expected the path with the optional segment present to match")
+	}
+	if params["format"] != "html" {
+		t.Fatalf("expected format=html, got %q", params["format"])
+	}
+
+	if _, ok := route.Parse("/posts"); ok {
+		t.Fatalf("expected a path missing the mandatory id segment to fail")
+	}
+}
+
+func TestRouteParseCatchAll(t *testing.T) {
+	route := &Route{Pattern: "/files/{path:*}"}
+	route.compile()
+
+	params, ok := route.Parse("/files/a/b/c.txt")
+	if !ok {
+		t.Fatalf("expected catch-all route to match")
+	}
+	if params["path"] != "a/b/c.txt" {
+		t.Fatalf("expected path=a/b/c.txt, got %q", params["path"])
+	}
+}
+
+// TestRouteParseConcurrentSafe guards against Parse mutating the shared
+// *Route, since the same Route is matched from many request goroutines at
+// once. Run with -race to catch regressions.
+func TestRouteParseConcurrentSafe(t *testing.T) {
+	route := &Route{Pattern: "/accounts/{id:\\d+}"}
+	route.compile()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			params, ok := route.Parse("/accounts/1")
+			if !ok || params["id"] != "1" {
+				t.Errorf("request A observed id=%q ok=%v, want id=1", params["id"], ok)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			params, ok := route.Parse("/accounts/2")
+			if !ok || params["id"] != "2" {
+				t.Errorf("request B observed id=%q ok=%v, want id=2", params["id"], ok)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,161 @@
+package router
+
+import "net/http"
+
+// Router dispatches incoming requests to registered Routes, applying any
+// router-wide middleware ahead of each route's own middleware stack
+type Router struct {
+	routes     []*Route
+	middleware []Middleware
+
+	// NotFound handles requests that match no route; defaults to a plain 404
+	NotFound Handler
+
+	// ErrorHandler renders errors returned by handlers; defaults to DefaultErrorHandler
+	ErrorHandler ErrorHandler
+
+	// MaxMemory is the amount of request body kept in memory by
+	// ParseMultipartForm before spilling to temp files; defaults to 32MB,
+	// matching net/http's own default
+	MaxMemory int64
+
+	// MaxUploadSize caps the total size of a request body read via FormFile /
+	// FormFiles / MultipartIter; 0 means unlimited. Override per-route with
+	// Route.MaxUploadSize
+	MaxUploadSize int64
+
+	// Secret signs and verifies Context.SignedRedirect URLs and VerifySignedURL
+	// requests. Required before either is used
+	Secret []byte
+
+	logger Logger
+	config Config
+}
+
+// New creates a Router backed by the given logger and config
+func New(logger Logger, config Config) *Router {
+	return &Router{
+		logger: logger,
+		config: config,
+		NotFound: func(c *Context) ResponseData {
+			return ResponseData{Status: http.StatusNotFound, Body: []byte("404 page not found")}
+		},
+		ErrorHandler: DefaultErrorHandler,
+		MaxMemory:    defaultMaxMemory,
+	}
+}
+
+// Use appends middleware to the router's global stack, applied to every route
+// ahead of the route's own middleware (added via Route.With)
+func (rt *Router) Use(mws ...Middleware) {
+	rt.middleware = append(rt.middleware, mws...)
+}
+
+// Handle registers a handler for method and pattern, returning the Route so
+// callers can attach route-specific middleware via Route.With
+func (rt *Router) Handle(method, pattern string, h HandlerFunc) *Route {
+	route := &Route{Method: method, Pattern: pattern, handler: Adapt(h)}
+	route.compile()
+	rt.routes = append(rt.routes, route)
+	return route
+}
+
+// Get registers a GET handler for pattern
+func (rt *Router) Get(pattern string, h HandlerFunc) *Route {
+	return rt.Handle(http.MethodGet, pattern, h)
+}
+
+// Post registers a POST handler for pattern
+func (rt *Router) Post(pattern string, h HandlerFunc) *Route {
+	return rt.Handle(http.MethodPost, pattern, h)
+}
+
+// Group returns a RouteGroup that registers routes under prefix, with mws
+// applied to every route registered through it in addition to the router's
+// own global middleware
+func (rt *Router) Group(prefix string, mws ...Middleware) *RouteGroup {
+	return &RouteGroup{router: rt, prefix: prefix, middleware: mws}
+}
+
+// RouteGroup attaches routes under a common path prefix and middleware stack,
+// e.g. an API version or an admin area sharing auth middleware
+type RouteGroup struct {
+	router     *Router
+	prefix     string
+	middleware []Middleware
+}
+
+// Handle registers a handler for method and the group's prefix joined with pattern
+func (g *RouteGroup) Handle(method, pattern string, h HandlerFunc) *Route {
+	route := g.router.Handle(method, g.prefix+pattern, h)
+	return route.With(g.middleware...)
+}
+
+// Get registers a GET handler under the group
+func (g *RouteGroup) Get(pattern string, h HandlerFunc) *Route {
+	return g.Handle(http.MethodGet, pattern, h)
+}
+
+// Post registers a POST handler under the group
+func (g *RouteGroup) Post(pattern string, h HandlerFunc) *Route {
+	return g.Handle(http.MethodPost, pattern, h)
+}
+
+// match finds the first route matching method and path, returning the
+// params extracted from path for that request. It does not mutate any
+// *Route, so it is safe to call concurrently from multiple requests
+// sharing the same routing table
+func (rt *Router) match(method, path string) (*Route, map[string]string) {
+	for _, route := range rt.routes {
+		if route.Method != method {
+			continue
+		}
+		if params, ok := route.Parse(path); ok {
+			return route, params
+		}
+	}
+	return nil, nil
+}
+
+// ServeHTTP implements http.Handler, dispatching req to its matching Route
+// through the router's global middleware and the route's own middleware. A
+// request that matches no route still runs through the router's global
+// middleware, with rt.NotFound standing in for the route handler
+func (rt *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	path := req.URL.Path
+	route, routeParams := rt.match(req.Method, path)
+
+	maxUploadSize := rt.MaxUploadSize
+	if route != nil && route.MaxUploadSize != 0 {
+		maxUploadSize = route.MaxUploadSize
+	}
+
+	ctx := &Context{
+		Writer:        w,
+		Request:       req,
+		Path:          path,
+		Route:         route,
+		routeParams:   routeParams,
+		logger:        rt.logger,
+		config:        rt.config,
+		maxMemory:     rt.MaxMemory,
+		maxUploadSize: maxUploadSize,
+		secret:        rt.Secret,
+		ctx:           req.Context(),
+	}
+
+	base := rt.NotFound
+	var routeMiddleware []Middleware
+	if route != nil {
+		base = route.handler
+		routeMiddleware = route.middleware
+	}
+
+	handler := Chain(base, append(append([]Middleware{}, rt.middleware...), routeMiddleware...)...)
+	resp := handler(ctx)
+	if resp.Err != nil {
+		rt.ErrorHandler(ctx, resp.Err)
+		return
+	}
+	writeResponse(w, resp)
+}
@@ -0,0 +1,44 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type testConfig struct{}
+
+func (testConfig) Config(string) string { return "" }
+func (testConfig) Production() bool     { return false }
+
+// TestNotFoundRunsThroughMiddleware guards against unmatched requests
+// bypassing Router.Use entirely, so middleware such as request-id or
+// logging never saw 404s.
+func TestNotFoundRunsThroughMiddleware(t *testing.T) {
+	rt := New(testLogger{t}, testConfig{})
+
+	var ran bool
+	rt.Use(func(next Handler) Handler {
+		return func(c *Context) ResponseData {
+			ran = true
+			return next(c)
+		}
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/missing", nil)
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, req)
+
+	if !ran {
+		t.Fatalf("expected global middleware to run on a 404")
+	}
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+}
+
+type testLogger struct{ t *testing.T }
+
+func (l testLogger) Printf(format string, v ...interface{}) {
+	l.t.Logf(format, v...)
+}
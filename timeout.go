@@ -0,0 +1,112 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// timeoutBuffer is an http.ResponseWriter that buffers writes in memory
+// instead of writing straight to the client. Router.Timeout hands one of
+// these to the handler goroutine so that, if the deadline fires first, the
+// still-running handler keeps writing to the buffer (which nobody ever
+// flushes) instead of racing the real ResponseWriter
+type timeoutBuffer struct {
+	mu     sync.Mutex
+	header http.Header
+	status int
+	body   bytes.Buffer
+	wrote  bool
+}
+
+func newTimeoutBuffer() *timeoutBuffer {
+	return &timeoutBuffer{header: make(http.Header)}
+}
+
+func (b *timeoutBuffer) Header() http.Header {
+	return b.header
+}
+
+func (b *timeoutBuffer) WriteHeader(status int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.wrote {
+		b.status = status
+		b.wrote = true
+	}
+}
+
+func (b *timeoutBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.wrote {
+		b.status = http.StatusOK
+		b.wrote = true
+	}
+	return b.body.Write(p)
+}
+
+// flushTo copies the buffered header, status and body to w. Only called
+// once the handler has finished within the deadline, so there is no
+// concurrent writer left to race w
+func (b *timeoutBuffer) flushTo(w http.ResponseWriter) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	dst := w.Header()
+	for k, v := range b.header {
+		dst[k] = v
+	}
+	if b.wrote {
+		w.WriteHeader(b.status)
+	}
+	w.Write(b.body.Bytes())
+}
+
+// Timeout returns middleware that aborts the handler chain with a 503 if it
+// has not produced a response within d. The handler runs in its own
+// goroutine against a private copy of the Context, writing to a buffering
+// ResponseWriter rather than the real one, so if it is still running when
+// the deadline fires its eventual writes and field mutations (c.Errors, ...)
+// land harmlessly on that copy instead of racing the real ResponseWriter or
+// the shared *Context still held by the rest of the middleware chain
+// (mirroring how net/http.TimeoutHandler avoids the same ResponseWriter
+// race). Check c.Done()/c.Err() in long-running handlers to stop early once
+// the deadline passes
+func (rt *Router) Timeout(d time.Duration) Middleware {
+	return func(next Handler) Handler {
+		return func(c *Context) ResponseData {
+			ctx, cancel := context.WithTimeout(c.ctx, d)
+			defer cancel()
+			c.ctx = ctx
+
+			// bg is a private copy of c for the handler goroutine: if the
+			// deadline fires first, bg keeps running concurrently with the
+			// caller, which still holds c, so nothing it mutates may alias c
+			buf := newTimeoutBuffer()
+			bg := *c
+			bg.Writer = buf
+
+			done := make(chan ResponseData, 1)
+			go func() {
+				done <- next(&bg)
+			}()
+
+			select {
+			case resp := <-done:
+				// the handler finished before the deadline, so it is safe to
+				// fold its accumulated errors back onto the shared Context
+				c.Errors = bg.Errors
+				buf.flushTo(c.Writer)
+				return resp
+			case <-ctx.Done():
+				// next(&bg) may still be running in the background, writing
+				// into buf and mutating bg; c itself is left untouched so it
+				// remains safe for the rest of the chain to keep using
+				return ResponseData{Status: http.StatusServiceUnavailable, Body: []byte("request timed out")}
+			}
+		}
+	}
+}
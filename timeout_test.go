@@ -0,0 +1,81 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestTimeoutWritesOnlyOneResponse guards against a slow handler writing to
+// the real ResponseWriter after Timeout already wrote its own 503,
+// corrupting the response. The slow handler here writes to c.Writer well
+// after the timeout fires; none of it should reach the client's recorder.
+func TestTimeoutWritesOnlyOneResponse(t *testing.T) {
+	rt := New(testLogger{t}, testConfig{})
+
+	slow := Adapt(func(c *Context) error {
+		time.Sleep(50 * time.Millisecond)
+		c.Writer.WriteHeader(http.StatusOK)
+		c.Writer.Write([]byte("too late"))
+		return nil
+	})
+
+	handler := rt.Timeout(5 * time.Millisecond)(slow)
+
+	req := httptest.NewRequest(http.MethodGet, "/slow", nil)
+	ctx := &Context{Writer: httptest.NewRecorder(), Request: req, ctx: req.Context()}
+	w := ctx.Writer.(*httptest.ResponseRecorder)
+
+	resp := handler(ctx)
+	if resp.Status != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 ResponseData, got %d", resp.Status)
+	}
+
+	writeResponse(w, resp)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 written to client, got %d", w.Code)
+	}
+
+	// Let the abandoned handler goroutine finish; its writes must have gone
+	// to the timeoutBuffer, not to w.
+	time.Sleep(100 * time.Millisecond)
+	if strings.Contains(w.Body.String(), "too late") {
+		t.Fatalf("expected the slow handler's write to be buffered away from the real writer, got %q", w.Body.String())
+	}
+}
+
+// TestTimeoutDoesNotRaceOuterMiddleware guards against the abandoned handler
+// goroutine mutating the shared *Context (e.g. via c.Error) concurrently
+// with outer middleware that reads from the same Context after Timeout
+// returns its 503. Run with -race: the abandoned goroutine must only touch
+// its own private copy of the Context, never the one outer middleware holds.
+func TestTimeoutDoesNotRaceOuterMiddleware(t *testing.T) {
+	rt := New(testLogger{t}, testConfig{})
+
+	slow := Adapt(func(c *Context) error {
+		time.Sleep(50 * time.Millisecond)
+		return c.Error(http.StatusInternalServerError, "too late")
+	})
+
+	timedOut := rt.Timeout(5 * time.Millisecond)(slow)
+	outer := func(next Handler) Handler {
+		return func(c *Context) ResponseData {
+			resp := next(c)
+			_ = len(c.Errors) // read right after next returns, racing the abandoned goroutine pre-fix
+			return resp
+		}
+	}(timedOut)
+
+	req := httptest.NewRequest(http.MethodGet, "/slow", nil)
+	ctx := &Context{Writer: httptest.NewRecorder(), Request: req, ctx: req.Context()}
+
+	resp := outer(ctx)
+	if resp.Status != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 ResponseData, got %d", resp.Status)
+	}
+
+	// Let the abandoned handler goroutine finish before the test exits.
+	time.Sleep(100 * time.Millisecond)
+}
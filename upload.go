@@ -0,0 +1,173 @@
+package router
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+)
+
+// defaultMaxMemory matches net/http's own default: the amount of a
+// multipart request kept in memory before spilling remaining parts to disk
+const defaultMaxMemory = 32 << 20 // 32 MB
+
+// ErrRequestBodyTooLarge is returned when a request body exceeds the
+// router's or route's configured MaxUploadSize
+var ErrRequestBodyTooLarge = errors.New("router: request body too large")
+
+// FileUpload is a single uploaded file, as returned by FormFile, FormFiles
+// or MultipartIterator.Next
+type FileUpload struct {
+	Filename    string
+	ContentType string
+	Size        int64
+
+	io.ReadCloser
+}
+
+// SaveTo writes the upload's contents to path, creating or truncating it
+func (f *FileUpload) SaveTo(path string) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, f)
+	return err
+}
+
+// limitBody wraps c.Request.Body with http.MaxBytesReader when a
+// MaxUploadSize is configured, so oversized bodies fail fast rather than
+// being read in full
+func (c *Context) limitBody() {
+	if c.maxUploadSize > 0 {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, c.maxUploadSize)
+	}
+}
+
+// maxMemoryOrDefault returns the configured MaxMemory, falling back to defaultMaxMemory
+func (c *Context) maxMemoryOrDefault() int64 {
+	if c.maxMemory > 0 {
+		return c.maxMemory
+	}
+	return defaultMaxMemory
+}
+
+// parseMultipart ensures the request's multipart form has been parsed,
+// enforcing MaxUploadSize and translating the resulting "too large" error
+// into ErrRequestBodyTooLarge
+func (c *Context) parseMultipart() error {
+	if c.Request.MultipartForm != nil {
+		return nil
+	}
+
+	c.limitBody()
+
+	err := c.Request.ParseMultipartForm(c.maxMemoryOrDefault())
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return ErrRequestBodyTooLarge
+		}
+		return err
+	}
+	return nil
+}
+
+// fileUploadFromHeader builds a FileUpload from a *multipart.FileHeader
+func fileUploadFromHeader(fh *multipart.FileHeader) (*FileUpload, error) {
+	f, err := fh.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileUpload{
+		Filename:    fh.Filename,
+		ContentType: fh.Header.Get("Content-Type"),
+		Size:        fh.Size,
+		ReadCloser:  f,
+	}, nil
+}
+
+// FormFile returns the first uploaded file for form field key
+func (c *Context) FormFile(key string) (*FileUpload, error) {
+	if err := c.parseMultipart(); err != nil {
+		return nil, err
+	}
+
+	files := c.Request.MultipartForm.File[key]
+	if len(files) == 0 {
+		return nil, http.ErrMissingFile
+	}
+
+	return fileUploadFromHeader(files[0])
+}
+
+// FormFiles returns every uploaded file for form field key
+func (c *Context) FormFiles(key string) ([]*FileUpload, error) {
+	if err := c.parseMultipart(); err != nil {
+		return nil, err
+	}
+
+	headers := c.Request.MultipartForm.File[key]
+	uploads := make([]*FileUpload, 0, len(headers))
+	for _, fh := range headers {
+		upload, err := fileUploadFromHeader(fh)
+		if err != nil {
+			return nil, err
+		}
+		uploads = append(uploads, upload)
+	}
+
+	return uploads, nil
+}
+
+// MultipartIterator streams the parts of a multipart request one at a time,
+// without buffering the whole body or spilling to temp files; use this for
+// large uploads instead of FormFile/FormFiles
+type MultipartIterator struct {
+	reader *multipart.Reader
+}
+
+// Next returns the next file part in the request, or io.EOF when done.
+// Non-file parts (form fields without a filename) are skipped. If the body
+// exceeds MaxUploadSize while advancing, Next returns ErrRequestBodyTooLarge;
+// a read from a returned part that crosses the limit instead returns the
+// *http.MaxBytesError from http.MaxBytesReader
+func (it *MultipartIterator) Next() (*FileUpload, error) {
+	for {
+		part, err := it.reader.NextPart()
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return nil, ErrRequestBodyTooLarge
+			}
+			return nil, err
+		}
+
+		if part.FileName() == "" {
+			continue
+		}
+
+		return &FileUpload{
+			Filename:    part.FileName(),
+			ContentType: part.Header.Get("Content-Type"),
+			ReadCloser:  part,
+		}, nil
+	}
+}
+
+// MultipartIter returns a MultipartIterator over the request body, enforcing
+// MaxUploadSize via http.MaxBytesReader but never buffering parts in memory
+func (c *Context) MultipartIter() (*MultipartIterator, error) {
+	c.limitBody()
+
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+
+	return &MultipartIterator{reader: reader}, nil
+}
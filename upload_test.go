@@ -0,0 +1,68 @@
+package router
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newMultipartRequest(t *testing.T, fieldName, filename string, content []byte) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile(fieldName, filename)
+	if err != nil {
+		t.Fatalf("CreateFormFile: %v", err)
+	}
+	if _, err := part.Write(content); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := mw.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req
+}
+
+func TestFormFileReadsContent(t *testing.T) {
+	content := []byte("hello upload")
+	req := newMultipartRequest(t, "file", "hello.txt", content)
+	w := httptest.NewRecorder()
+	ctx := &Context{Writer: w, Request: req, maxMemory: defaultMaxMemory}
+
+	upload, err := ctx.FormFile("file")
+	if err != nil {
+		t.Fatalf("FormFile: %v", err)
+	}
+	defer upload.Close()
+
+	if upload.Filename != "hello.txt" {
+		t.Fatalf("expected filename hello.txt, got %q", upload.Filename)
+	}
+
+	got := make([]byte, len(content))
+	if _, err := upload.Read(got); err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Fatalf("expected content %q, got %q", content, got)
+	}
+}
+
+func TestFormFileEnforcesMaxUploadSize(t *testing.T) {
+	content := bytes.Repeat([]byte("x"), 1024)
+	req := newMultipartRequest(t, "file", "big.txt", content)
+	w := httptest.NewRecorder()
+	ctx := &Context{Writer: w, Request: req, maxMemory: defaultMaxMemory, maxUploadSize: 16}
+
+	_, err := ctx.FormFile("file")
+	if !errors.Is(err, ErrRequestBodyTooLarge) {
+		t.Fatalf("expected ErrRequestBodyTooLarge, got %v", err)
+	}
+}